@@ -0,0 +1,33 @@
+// Copyright (c) 2012 Graeme Connell. All rights reserved.
+// Copyright (c) 2009-2012 Andreas Krennmair. All rights reserved.
+
+package gopacket
+
+import "time"
+
+// CaptureInfo provides standardized information about captured packet data,
+// independent of the actual packet data itself.  Packet sources fill this in
+// and hand it to PacketDecoder.DecodePacket alongside the wire bytes, since
+// this information doesn't come from the bytes themselves.
+type CaptureInfo struct {
+	// Timestamp is the time the packet was captured, if that is known.
+	Timestamp time.Time
+	// CaptureLength is the number of bytes of the packet that were actually
+	// captured and are available to decode.
+	CaptureLength int
+	// Length is the size of the original packet, which may be larger than
+	// CaptureLength if the packet source truncated it, e.g. due to a pcap
+	// snaplen.
+	Length int
+	// InterfaceIndex is the index of the interface the packet was captured
+	// from, matching the value returned by net.Interface.Index for that
+	// interface.  It's left at its zero value if the packet source doesn't
+	// know or doesn't have multiple interfaces to distinguish.
+	InterfaceIndex int
+	// AncillaryData contains extra information the packet source attached to
+	// this packet that doesn't come from the wire bytes themselves, e.g. a
+	// VLAN tag, RX hash, or hardware timestamp added by an AF_PACKET source.
+	// Consumers should type-switch on the elements, since its contents are
+	// specific to the packet source that set them.
+	AncillaryData []interface{}
+}
@@ -6,9 +6,99 @@ package docker
 
 import (
 	"encoding/json"
-	"github.com/dotcloud/docker"
+	"time"
 )
 
+// APIPort describes a port exposed by a container, as reported by
+// ListContainers.
+type APIPort struct {
+	PrivatePort int64
+	PublicPort  int64
+	Type        string
+	IP          string
+}
+
+// APIContainers represents a container as reported by ListContainers.
+//
+// See http://goo.gl/8IMr2 for more details.
+type APIContainers struct {
+	ID      string `json:"Id"`
+	Image   string
+	Command string
+	Created int64
+	Status  string
+	Ports   []APIPort
+	Labels  map[string]string
+}
+
+// State represents the state of a container, as reported by InspectContainer.
+type State struct {
+	Running   bool
+	Pid       int
+	ExitCode  int
+	StartedAt time.Time
+	Ghost     bool
+}
+
+// Config is the configuration for a container, used when creating it via
+// CreateContainer.
+//
+// See http://goo.gl/eSVdT for more details.
+type Config struct {
+	Hostname        string
+	Domainname      string
+	User            string
+	Memory          int64
+	MemorySwap      int64
+	CPUShares       int64 `json:"CpuShares"`
+	AttachStdin     bool
+	AttachStdout    bool
+	AttachStderr    bool
+	Tty             bool
+	OpenStdin       bool
+	StdinOnce       bool
+	Env             []string
+	Cmd             []string
+	Image           string
+	Volumes         map[string]struct{}
+	WorkingDir      string
+	Entrypoint      []string
+	NetworkDisabled bool
+	Labels          map[string]string
+}
+
+// Container is the representation of a container returned by
+// InspectContainer.
+//
+// See http://goo.gl/g5tpG for more details.
+type Container struct {
+	ID string `json:"Id"`
+
+	Created time.Time
+
+	Path string
+	Args []string
+
+	Config *Config
+	State  State
+	Image  string
+
+	NetworkSettings *NetworkSettings
+
+	Name       string
+	HostConfig *HostConfig
+	Labels     map[string]string
+}
+
+// NetworkSettings contains network-related information about a container.
+type NetworkSettings struct {
+	IPAddress   string
+	IPPrefixLen int
+	Gateway     string
+	Bridge      string
+	PortMapping map[string]map[string]string
+}
+
 // ListContainersOptions specify parameters to the ListContainers function.
 //
 // See http://goo.gl/8IMr2 for more details.
@@ -17,18 +107,21 @@ type ListContainersOptions struct {
 	Limit  int
 	Since  string
 	Before string
+	// Filters restricts the returned containers, e.g.
+	// Filters: map[string][]string{"status": {"running"}, "label": {"env=prod"}}.
+	Filters map[string][]string
 }
 
 // ListContainers returns a slice of containers matching the given criteria.
 //
 // See http://goo.gl/8IMr2 for more details.
-func (c *Client) ListContainers(opts *ListContainersOptions) ([]docker.ApiContainer, error) {
+func (c *Client) ListContainers(opts *ListContainersOptions) ([]APIContainers, error) {
 	path := "/containers/ps?" + queryString(opts)
 	body, _, err := c.do("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
-	var containers []docker.ApiContainer
+	var containers []APIContainers
 	err = json.Unmarshal(body, &containers)
 	if err != nil {
 		return nil, err
@@ -39,13 +132,13 @@ func (c *Client) ListContainers(opts *ListContainersOptions) ([]docker.ApiContai
 // InspectContainer returns information about a container by its ID.
 //
 // See http://goo.gl/g5tpG for more details.
-func (c *Client) InspectContainer(id string) (*docker.Container, error) {
+func (c *Client) InspectContainer(id string) (*Container, error) {
 	path := "/containers/" + id + "/json"
 	body, _, err := c.do("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
-	var container docker.Container
+	var container Container
 	err = json.Unmarshal(body, &container)
 	if err != nil {
 		return nil, err
@@ -54,15 +147,19 @@ func (c *Client) InspectContainer(id string) (*docker.Container, error) {
 }
 
 // CreateContainer creates a new container, returning the container instance,
-// or an error in case of failure.
+// or an error in case of failure. hostConfig may be nil; when given, its
+// Binds and Mounts let callers attach volumes created via CreateVolume.
 //
 // See http://goo.gl/lcR51 for more details.
-func (c *Client) CreateContainer(config *docker.Config) (*docker.Container, error) {
-	body, _, err := c.do("POST", "/containers/create", config)
+func (c *Client) CreateContainer(config *Config, hostConfig *HostConfig) (*Container, error) {
+	body, _, err := c.do("POST", "/containers/create", struct {
+		*Config
+		HostConfig *HostConfig
+	}{config, hostConfig})
 	if err != nil {
 		return nil, err
 	}
-	var container docker.Container
+	var container Container
 	err = json.Unmarshal(body, &container)
 	if err != nil {
 		return nil, err
@@ -91,4 +188,4 @@ func (c *Client) RemoveContainer(id string) error {
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
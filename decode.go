@@ -7,47 +7,61 @@ import (
 	"errors"
 )
 
-type decodeResult struct {
-	// An error encountered in this decode call.  If this is set, everything else
-	// will be ignored.
-	err error
-	// The layer we've created with this decode call
-	layer Layer
-	// The next decoder to call
-	next decoder
-	// The bytes that are left to be decoded
-	left []byte
+// PacketBuilder is used by layer decoders to store the layers they've
+// decoded, and to defer decoding of the next layer to the decoder
+// responsible for it.
+//
+// This interface is implemented by the packet itself, and passed to each
+// Decoder in turn to build up the list of layers a packet contains, and
+// set the packet's link/network/transport/application/error layer so it
+// can be quickly retrieved later.
+type PacketBuilder interface {
+	// DecodeFeedback is embedded so that decoders can report things like
+	// truncation back to the packet as they decode, through the same
+	// PacketBuilder they're already passed.
+	DecodeFeedback
+	// AddLayer should be called by a decoder immediately upon successful
+	// decoding of a layer, to add that layer to the packet's list of
+	// layers.
+	AddLayer(l Layer)
+	// The following functions set the various specific layers in the
+	// packet.  Decoders should call the appropriate one of these for the
+	// layer they've just decoded, if any; a given packet should have at
+	// most one of each.
+	SetLinkLayer(LinkLayer)
+	SetNetworkLayer(NetworkLayer)
+	SetTransportLayer(TransportLayer)
+	SetApplicationLayer(ApplicationLayer)
+	SetErrorLayer(ErrorLayer)
+	// NextDecoder should be called by a decoder when it's finished decoding
+	// the layer it's responsible for, to hand off decoding of whatever
+	// bytes are left to the given Decoder.  Each Decoder's Decode function
+	// should return the result of calling NextDecoder (or nil, if there's
+	// nothing left to decode).
+	NextDecoder(next Decoder) error
 }
 
-// decoder decodes the next layer in a packet.  It returns a set of useful
-// information, which is used by the packet decoding logic to update packet
-// state.  Optionally, the decode function may set any of the specificLayer
-// pointers to point to the new layer it has created.
-//
-// This decoder interface is the internal interface used by gopacket to store
-// the next method to use for decoding the rest of the data available in the
-// packet.  It should exhibit the following behavior:
-// * if there's an error, set decodeResult.err.  All other fields will be
-//   ignored and a DecodeError layer will be created with that error.
-// * if there's NOT an error, set layer to the layer created by this decoder,
-//   next to the next decoder to run, and left to the bytes not yet processed.
-//   if either decoder is nil or left is empty, this packet's decoding is
-//   considered complete and nothing else is done.
-//
-// If the decoded layer is one of the specific layers in specificLayers, the
-// function should set specificLayers' pointer to the new layer.  For example,
-// note how decodeIp4 sets specificLayers' network pointer to the newly created
-// IPv4 layer object.
-type decoder interface {
-	decode([]byte, *specificLayers) decodeResult
+// Decoder decodes a layer of packet data and stores the result in the given
+// PacketBuilder, handing off the remaining bytes to the next decoder via
+// PacketBuilder.NextDecoder.  Unlike the old decoder interface, a Decoder
+// implementation doesn't need to live in this package: anyone can write a
+// Decoder for their own protocol and call it directly or hand it to
+// PacketBuilder.NextDecoder.  DecodingLayerParser does not take Decoders;
+// it only accepts DecodingLayer implementations.
+type Decoder interface {
+	// Decode decodes the given bytes, adding decoded Layers and metadata to
+	// the given PacketBuilder, and possibly calling PacketBuilder.NextDecoder
+	// to hand off decoding of the remainder of the bytes to another decoder.
+	Decode(data []byte, p PacketBuilder) error
 }
 
-// decoderFunc is an implementation of decoder that's a simple function.
-type decoderFunc func([]byte, *specificLayers) decodeResult
+// DecodeFunc wraps a function to implement the Decoder interface.
+type DecodeFunc func([]byte, PacketBuilder) error
 
-func (d decoderFunc) decode(data []byte, s *specificLayers) decodeResult {
+// Decode implements Decoder.Decode for DecodeFunc.
+func (d DecodeFunc) Decode(data []byte, p PacketBuilder) error {
 	// function, call thyself.
-	return d(data, s)
+	return d(data, p)
 }
 
 // DecodeMethod tells gopacket how to decode a packet.
@@ -69,9 +83,34 @@ const (
 // PacketDecoder provides the functionality to decode a set of bytes into a
 // packet, and decode that packet into one or more layers.
 type PacketDecoder interface {
-	Decode(data []byte, method DecodeMethod) Packet
+	// DecodePacket decodes data into a Packet, using ci for metadata that
+	// doesn't come from the wire bytes themselves, such as capture
+	// timestamp and interface index.
+	DecodePacket(data []byte, ci CaptureInfo, method DecodeMethod) Packet
 }
 
+// DecodeFeedback lets a decoder provide feedback about the decoding process
+// to whatever's driving it, separate from the layers it decodes.  Most
+// decoders will only ever call SetTruncated, to flag that the packet's
+// declared length doesn't match the number of bytes actually available,
+// without having to fail the decode outright with a DecodeFailure layer.
+type DecodeFeedback interface {
+	// SetTruncated should be called if during decoding we notice that a
+	// packet is shorter than we expect based on the protocol we're decoding,
+	// e.g. an IPv4 header that declares more payload than is actually
+	// present.
+	SetTruncated()
+}
+
+// nilDecodeFeedback is a DecodeFeedback that ignores everything it's told.
+type nilDecodeFeedback struct{}
+
+func (nilDecodeFeedback) SetTruncated() {}
+
+// NilDecodeFeedback implements DecodeFeedback by doing nothing.  Use it when
+// calling a decoder directly and you don't care about its feedback.
+var NilDecodeFeedback DecodeFeedback = nilDecodeFeedback{}
+
 // DecodeFailure is a packet layer created if decoding of the packet data failed
 // for some reason.  It implements ErrorLayer.
 type DecodeFailure struct {
@@ -79,26 +118,105 @@ type DecodeFailure struct {
 	err  error
 }
 
-// Returns the entire payload which failed to be decoded.
+// Payload returns the entire payload which failed to be decoded.
 func (d *DecodeFailure) Payload() []byte { return d.data }
 
-// Returns the error encountered during decoding.
+// Error returns the error encountered during decoding.
 func (d *DecodeFailure) Error() error { return d.err }
 
-// Returns TYPE_DECODE_FAILURE
+// LayerType returns TYPE_DECODE_FAILURE.
 func (d *DecodeFailure) LayerType() LayerType { return TYPE_DECODE_FAILURE }
 
 // decodeUnknown "decodes" unsupported data types by returning an error.
-// This decoder will thus always return a DecodeFailure layer.
-var decodeUnknown decoderFunc = func(data []byte, _ *specificLayers) (out decodeResult) {
-	out.err = errors.New("Link type not currently supported")
-	return
-}
+// This decoder will thus always return an error.
+//
+// decodeUnknown and decodePayload are the only Decoders in this package;
+// protocol decoders (IPv4, Ethernet, TCP, ...) aren't implemented here and
+// so don't yet exercise this API or DecodingLayerParser's fast path -
+// they're expected to be written as Decoders the same way decodeUnknown and
+// decodePayload are, in whatever package ends up defining them.
+var decodeUnknown Decoder = DecodeFunc(func(data []byte, p PacketBuilder) error {
+	return errors.New("Link type not currently supported")
+})
 
-// decodePayload decodes data by returning it all in a Payload layer.
-var decodePayload decoderFunc = func(data []byte, s *specificLayers) (out decodeResult) {
+// decodePayload decodes data by adding it all as a Payload layer, and sets
+// that layer as the packet's application layer.
+var decodePayload Decoder = DecodeFunc(func(data []byte, p PacketBuilder) error {
 	payload := &Payload{Data: data}
-	out.layer = payload
-	s.application = payload
-	return
-}
\ No newline at end of file
+	p.AddLayer(payload)
+	p.SetApplicationLayer(payload)
+	return nil
+})
+
+// DecodingLayer is implemented by layer types that can decode themselves
+// in-place into a preallocated struct, instead of allocating a new Layer
+// on every decode.  DecodingLayerParser uses this to decode a fixed stack
+// of layer types with zero allocations per packet.
+type DecodingLayer interface {
+	Layer
+	// DecodeFromBytes resets the internal state of this layer to the given
+	// bytes.
+	DecodeFromBytes(data []byte, df DecodeFeedback) error
+	// CanDecode returns the LayerType this DecodingLayer is able to decode.
+	CanDecode() LayerType
+	// NextLayerType returns the LayerType which should be used to decode
+	// the payload of this layer.
+	NextLayerType() LayerType
+}
+
+// DecodingLayerParser decodes a specific set of network layers into
+// preallocated, reusable DecodingLayer objects.  Unlike PacketDecoder, which
+// allocates a new Layer for every layer of every packet, DecodingLayerParser
+// is intended for hot paths that always expect the same stack of layer
+// types: it reuses the DecodingLayer objects it's given across calls,
+// avoiding per-packet allocation entirely.
+type DecodingLayerParser struct {
+	// First is the layer type to start decoding with.
+	First LayerType
+	df    DecodeFeedback
+
+	decoders map[LayerType]DecodingLayer
+}
+
+// NewDecodingLayerParser creates a DecodingLayerParser that starts decoding
+// with the given first layer type, storing each decoded layer's data into
+// the corresponding DecodingLayer passed in decoders.
+func NewDecodingLayerParser(first LayerType, decoders ...DecodingLayer) *DecodingLayerParser {
+	p := &DecodingLayerParser{
+		First:    first,
+		df:       NilDecodeFeedback,
+		decoders: make(map[LayerType]DecodingLayer, len(decoders)),
+	}
+	p.AddDecodingLayer(decoders...)
+	return p
+}
+
+// AddDecodingLayer registers additional DecodingLayers with the parser,
+// indexed by the LayerType each one handles.
+func (p *DecodingLayerParser) AddDecodingLayer(decoders ...DecodingLayer) {
+	for _, d := range decoders {
+		p.decoders[d.CanDecode()] = d
+	}
+}
+
+// DecodeLayers decodes the given data one layer at a time, starting at
+// p.First, feeding the payload of each decoded layer to the next, and
+// appending each layer's LayerType to decoded as it goes.  It stops as soon
+// as it runs out of data, hits a layer type with no registered decoder, or
+// hits a decode error.
+func (p *DecodingLayerParser) DecodeLayers(data []byte, decoded *[]LayerType) error {
+	typ := p.First
+	for len(data) > 0 {
+		decoder, ok := p.decoders[typ]
+		if !ok {
+			return nil
+		}
+		if err := decoder.DecodeFromBytes(data, p.df); err != nil {
+			return err
+		}
+		*decoded = append(*decoded, typ)
+		typ = decoder.NextLayerType()
+		data = decoder.LayerPayload()
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// APIActor describes the object an event happened to, e.g. the container
+// that started or died.
+type APIActor struct {
+	ID         string
+	Attributes map[string]string
+}
+
+// APIEvents represents an event returned by the /events API.
+//
+// See http://goo.gl/CMo0qZ for more details.
+type APIEvents struct {
+	Status   string
+	ID       string
+	From     string
+	Type     string
+	Action   string
+	Actor    APIActor
+	Time     int64
+	TimeNano int64
+}
+
+// eventMonitor owns the single long-lived /events connection backing every
+// listener registered via AddEventListener for one Client.
+type eventMonitor struct {
+	sync.Mutex
+	listeners []chan<- *APIEvents
+	closeChan chan struct{}
+}
+
+// eventMonitors tracks the eventMonitor for each Client that has an active
+// AddEventListener, keyed by Client identity. It's package-level, rather
+// than a field on Client, so that event support leaves Client's struct
+// layout as it is.
+var (
+	eventMonitorsLock sync.Mutex
+	eventMonitors     = make(map[*Client]*eventMonitor)
+)
+
+// AddEventListener registers ch to receive every event reported by the
+// daemon. The first call for a given Client opens a long-lived streaming
+// GET to /events; later calls reuse it. The underlying connection is
+// reconnected with exponential backoff if it drops, whether the drop
+// happens on connect or partway through reading, until Close is called.
+//
+// See http://goo.gl/CMo0qZ for more details.
+func (c *Client) AddEventListener(ch chan<- *APIEvents) error {
+	eventMonitorsLock.Lock()
+	m, ok := eventMonitors[c]
+	if !ok {
+		m = &eventMonitor{closeChan: make(chan struct{})}
+		eventMonitors[c] = m
+		go c.listenEvents(m)
+	}
+	eventMonitorsLock.Unlock()
+
+	m.Lock()
+	m.listeners = append(m.listeners, ch)
+	m.Unlock()
+	return nil
+}
+
+// RemoveEventListener stops ch from receiving events registered via
+// AddEventListener.
+func (c *Client) RemoveEventListener(ch chan *APIEvents) error {
+	eventMonitorsLock.Lock()
+	m, ok := eventMonitors[c]
+	eventMonitorsLock.Unlock()
+	if !ok {
+		return errors.New("docker: no event listeners registered")
+	}
+	m.Lock()
+	defer m.Unlock()
+	for i, l := range m.listeners {
+		if l == ch {
+			m.listeners = append(m.listeners[:i], m.listeners[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("docker: event listener not found")
+}
+
+// Close stops the event listener started by AddEventListener, if any, from
+// opening any further /events connections. A connection that is already
+// open is not closed: it keeps being read, and its events keep being
+// delivered to the registered listeners, until it fails or the daemon
+// closes it.
+func (c *Client) Close() error {
+	eventMonitorsLock.Lock()
+	m, ok := eventMonitors[c]
+	if ok {
+		delete(eventMonitors, c)
+	}
+	eventMonitorsLock.Unlock()
+	if !ok {
+		return nil
+	}
+	close(m.closeChan)
+	return nil
+}
+
+// listenEvents keeps re-opening the /events stream via readEvents until
+// m.closeChan is closed, backing off exponentially (see nextBackoff) between
+// attempts regardless of whether the previous attempt failed to connect or
+// failed partway through decoding.
+func (c *Client) listenEvents(m *eventMonitor) {
+	backoff := time.Second
+	for {
+		select {
+		case <-m.closeChan:
+			return
+		default:
+		}
+		if err := c.readEvents(m); err == nil {
+			backoff = time.Second
+			continue
+		}
+		select {
+		case <-m.closeChan:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// nextBackoff doubles d while it is under 30 seconds and returns it unchanged
+// once it is 30 seconds or more, so starting from one second the delay
+// settles at 32 seconds.
+func nextBackoff(d time.Duration) time.Duration {
+	if d < 30*time.Second {
+		return d * 2
+	}
+	return d
+}
+
+// readEvents opens one streaming GET /events connection and decodes
+// newline-delimited JSON events from it, fanning each one out to every
+// listener registered on m, until the connection fails or EOFs. It returns
+// that error (nil is never returned, since the daemon only stops sending
+// events by closing the connection) so listenEvents can apply backoff the
+// same way whichever stage failed.
+func (c *Client) readEvents(m *eventMonitor) error {
+	body, err := c.stream("GET", "/events", nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	dec := json.NewDecoder(body)
+	for {
+		var event APIEvents
+		if err := dec.Decode(&event); err != nil {
+			return err
+		}
+		m.Lock()
+		listeners := make([]chan<- *APIEvents, len(m.listeners))
+		copy(listeners, m.listeners)
+		m.Unlock()
+		for _, l := range listeners {
+			l <- &event
+		}
+	}
+}
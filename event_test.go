@@ -0,0 +1,26 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextBackoffDoublesUntilCap(t *testing.T) {
+	cases := []struct {
+		in, want time.Duration
+	}{
+		{time.Second, 2 * time.Second},
+		{16 * time.Second, 32 * time.Second},
+		{30 * time.Second, 30 * time.Second},
+		{45 * time.Second, 45 * time.Second},
+	}
+	for _, c := range cases {
+		if got := nextBackoff(c.in); got != c.want {
+			t.Errorf("nextBackoff(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
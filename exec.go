@@ -0,0 +1,189 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// Exec is the representation of a process run inside a running container.
+//
+// See http://goo.gl/gpmLN8 for more details.
+type Exec struct {
+	ID string `json:"Id"`
+}
+
+// ExecInspect holds information about an exec instance, as returned by
+// InspectExec.
+//
+// See http://goo.gl/gpmLN8 for more details.
+type ExecInspect struct {
+	ID        string `json:"Id"`
+	Running   bool
+	ExitCode  int
+	Container Container
+}
+
+// CreateExecOptions specify parameters to the CreateExec function.
+//
+// See http://goo.gl/gpmLN8 for more details.
+type CreateExecOptions struct {
+	Container    string `json:"-"`
+	Cmd          []string
+	AttachStdin  bool
+	AttachStdout bool
+	AttachStderr bool
+	Tty          bool
+	User         string
+}
+
+// CreateExec sets up an exec instance in a running container, returning the
+// exec instance, or an error in case of failure.
+//
+// See http://goo.gl/gpmLN8 for more details.
+func (c *Client) CreateExec(opts CreateExecOptions) (*Exec, error) {
+	path := "/containers/" + opts.Container + "/exec"
+	body, _, err := c.do("POST", path, opts)
+	if err != nil {
+		return nil, err
+	}
+	var exec Exec
+	err = json.Unmarshal(body, &exec)
+	if err != nil {
+		return nil, err
+	}
+	return &exec, nil
+}
+
+// InspectExec returns information about a created exec instance.
+//
+// See http://goo.gl/gpmLN8 for more details.
+func (c *Client) InspectExec(id string) (*ExecInspect, error) {
+	path := "/exec/" + id + "/json"
+	body, _, err := c.do("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var inspect ExecInspect
+	err = json.Unmarshal(body, &inspect)
+	if err != nil {
+		return nil, err
+	}
+	return &inspect, nil
+}
+
+// StartExecOptions specify parameters to the StartExec function.
+//
+// See http://goo.gl/gpmLN8 for more details.
+type StartExecOptions struct {
+	InputStream  io.Reader
+	OutputStream io.Writer
+	ErrorStream  io.Writer
+	Detach       bool
+	Tty          bool
+	RawTerminal  bool
+}
+
+// StartExec starts a previously created exec instance, streaming its
+// input/output over InputStream/OutputStream/ErrorStream if they're set.
+// When RawTerminal is false, the daemon's stream framing is demultiplexed
+// so stdout and stderr land on separate writers; with a tty (RawTerminal)
+// there's only one combined stream, so it's copied to OutputStream as-is.
+//
+// See http://goo.gl/gpmLN8 for more details.
+func (c *Client) StartExec(id string, opts StartExecOptions) error {
+	path := "/exec/" + id + "/start"
+	body, _, err := c.hijack("POST", path, struct {
+		Detach bool
+		Tty    bool
+	}{opts.Detach, opts.Tty}, !opts.Detach, opts.InputStream)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if opts.RawTerminal {
+		out := opts.OutputStream
+		if out == nil {
+			out = ioutil.Discard
+		}
+		_, err = io.Copy(out, body)
+		return err
+	}
+	return demuxStream(body, opts.OutputStream, opts.ErrorStream)
+}
+
+// AttachToContainerOptions specify parameters to AttachToContainer.
+//
+// See http://goo.gl/gpmLN8 for more details.
+type AttachToContainerOptions struct {
+	Container    string `json:"-"`
+	InputStream  io.Reader
+	OutputStream io.Writer
+	ErrorStream  io.Writer
+	Stream       bool
+	Stdin        bool
+	Stdout       bool
+	Stderr       bool
+	Logs         bool
+	RawTerminal  bool
+}
+
+// AttachToContainer attaches to a running container, streaming its
+// stdin/stdout/stderr over the given streams. It uses the same stream
+// demultiplexing as StartExec.
+//
+// See http://goo.gl/gpmLN8 for more details.
+func (c *Client) AttachToContainer(opts AttachToContainerOptions) error {
+	if opts.Container == "" {
+		return errors.New("no container specified")
+	}
+	path := "/containers/" + opts.Container + "/attach?" + queryString(opts)
+	body, _, err := c.hijack("POST", path, nil, opts.Stream, opts.InputStream)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if opts.RawTerminal {
+		out := opts.OutputStream
+		if out == nil {
+			out = ioutil.Discard
+		}
+		_, err = io.Copy(out, body)
+		return err
+	}
+	return demuxStream(body, opts.OutputStream, opts.ErrorStream)
+}
+
+// demuxStream splits the Docker multiplexed stream format into stdout and
+// stderr. Each frame starts with an 8-byte header: 1 byte stream type
+// (1=stdout, 2=stderr), 3 bytes unused, and a 4-byte big-endian payload
+// length, followed by that many bytes of payload.
+func demuxStream(src io.Reader, stdout, stderr io.Writer) error {
+	header := make([]byte, 8)
+	for {
+		_, err := io.ReadFull(src, header)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size := binary.BigEndian.Uint32(header[4:8])
+		w := stdout
+		if header[0] == 2 {
+			w = stderr
+		}
+		if w == nil {
+			w = ioutil.Discard
+		}
+		if _, err := io.CopyN(w, src, int64(size)); err != nil {
+			return err
+		}
+	}
+}
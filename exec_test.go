@@ -0,0 +1,50 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func frame(streamType byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = streamType
+	binary.BigEndian.PutUint32(header[4:8], uint32(len(payload)))
+	return append(header, []byte(payload)...)
+}
+
+func TestDemuxStreamSplitsStdoutAndStderr(t *testing.T) {
+	var src bytes.Buffer
+	src.Write(frame(1, "hello "))
+	src.Write(frame(2, "oops"))
+	src.Write(frame(1, "world"))
+
+	var stdout, stderr bytes.Buffer
+	if err := demuxStream(&src, &stdout, &stderr); err != nil {
+		t.Fatalf("demuxStream returned error: %v", err)
+	}
+	if got, want := stdout.String(), "hello world"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "oops"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestDemuxStreamNilWriterDiscardsFrame(t *testing.T) {
+	var src bytes.Buffer
+	src.Write(frame(2, "oops"))
+	src.Write(frame(1, "ok"))
+
+	var stdout bytes.Buffer
+	if err := demuxStream(&src, &stdout, nil); err != nil {
+		t.Fatalf("demuxStream returned error: %v", err)
+	}
+	if got, want := stdout.String(), "ok"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// hijack issues method/path to the daemon and, if upgrade is true and the
+// response announces a protocol upgrade (101 Switching Protocols, which
+// /exec/.../start and /containers/.../attach use to start streaming), takes
+// over the underlying connection so the caller can read/write it directly
+// instead of treating the response as an ordinary HTTP body. hijacked is
+// false for a plain response (upgrade was false, or the daemon answered
+// without switching protocols anyway), in which case the returned
+// ReadCloser is just that response's body — any bytes it already has are
+// preserved, not discarded, so callers should still read it. A plain
+// response with a status of 400 or above is returned as an error instead,
+// with no ReadCloser.
+//
+// upgrade should reflect whether the caller wants a live stream at all
+// (e.g. !Detach for StartExec), not merely whether it has stdin to send:
+// most exec/attach calls only want stdout/stderr back and have no
+// InputStream, but still need the upgrade to get a streaming connection.
+// If in is non-nil, it's copied to the connection in the background once
+// the upgrade succeeds, so callers can stream stdin alongside reading
+// stdout/stderr from the returned ReadCloser.
+func (c *Client) hijack(method, path string, data interface{}, upgrade bool, in io.Reader) (io.ReadCloser, bool, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var bodyReader io.Reader
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			conn.Close()
+			return nil, false, err
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, path, bodyReader)
+	if err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if upgrade {
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "tcp")
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		conn.Close()
+		if resp.StatusCode >= 400 {
+			return nil, false, fmt.Errorf("docker: %s %s: %s", method, path, body)
+		}
+		return ioutil.NopCloser(bytes.NewReader(body)), false, nil
+	}
+
+	if in != nil {
+		go io.Copy(conn, in)
+	}
+	return conn, true, nil
+}
+
+// dial opens a raw connection to the daemon, so hijack can take over the
+// connection directly instead of going through an http.Client. It dials a
+// unix socket for a "unix://" endpoint and a TCP connection otherwise, the
+// same split NewTLSClientFromBytes uses for its transport. If the Client
+// was built with NewTLSClient/NewTLSClientFromBytes, c.tlsConfig is set and
+// the tcp case dials straight into TLS, so exec/attach/event traffic gets
+// the same protection as everything going through c.do.
+func (c *Client) dial() (net.Conn, error) {
+	if strings.HasPrefix(c.endpoint, "unix://") {
+		return net.Dial("unix", strings.TrimPrefix(c.endpoint, "unix://"))
+	}
+	addr := strings.TrimPrefix(c.endpoint, "tcp://")
+	if c.tlsConfig != nil {
+		return tls.Dial("tcp", addr, c.tlsConfig)
+	}
+	return net.Dial("tcp", addr)
+}
@@ -0,0 +1,45 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+// Mount represents a single mount point for a container, as passed to the
+// daemon via HostConfig.Mounts.
+type Mount struct {
+	Source      string
+	Destination string
+	Mode        string
+	RW          bool
+}
+
+// HostConfig contains the container options related to starting a container
+// on the host, as opposed to those configuring the container image itself
+// (see Config).
+//
+// See http://goo.gl/EYWWtL for more details.
+type HostConfig struct {
+	// Binds is a list of "host:container[:ro]" bind mounts, in the same
+	// format accepted by `docker run -v`.
+	Binds []string
+	// Mounts is an alternative to Binds, describing volume mounts as
+	// structured values rather than "host:container[:ro]" strings.
+	Mounts          []Mount
+	Links           []string
+	PublishAllPorts bool
+	Privileged      bool
+	NetworkMode     string
+}
+
+// StartContainer starts a container, attaching any binds or mounts declared
+// in hostConfig.
+//
+// See http://goo.gl/iM5GKH for more details.
+func (c *Client) StartContainer(id string, hostConfig *HostConfig) error {
+	path := "/containers/" + id + "/start"
+	_, _, err := c.do("POST", path, hostConfig)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import "encoding/json"
+
+// IPAMConfig describes a single IPAM pool configuration for a network.
+type IPAMConfig struct {
+	Subnet  string
+	Gateway string
+}
+
+// IPAM describes the IP Address Management driver and pools for a network.
+type IPAM struct {
+	Driver string
+	Config []IPAMConfig
+}
+
+// Network represents a network, as reported by the /networks API.
+//
+// See http://goo.gl/6GugX2 for more details.
+type Network struct {
+	ID         string `json:"Id"`
+	Name       string
+	Driver     string
+	Scope      string
+	IPAM       IPAM
+	Containers map[string]struct {
+		IPv4Address string
+		IPv6Address string
+	}
+	Options map[string]string
+	Labels  map[string]string
+}
+
+// CreateNetworkOptions specify parameters to the CreateNetwork function.
+//
+// See http://goo.gl/6GugX2 for more details.
+type CreateNetworkOptions struct {
+	Name    string
+	Driver  string
+	IPAM    IPAM
+	Options map[string]string
+	Labels  map[string]string
+}
+
+// EndpointConfig describes a container's attachment to a network, used by
+// ConnectNetwork and DisconnectNetwork.
+type EndpointConfig struct {
+	IPv4Address string
+	IPv6Address string
+}
+
+// NetworkConnectionOptions specify parameters to ConnectNetwork and
+// DisconnectNetwork.
+//
+// See http://goo.gl/6GugX2 for more details.
+type NetworkConnectionOptions struct {
+	Container      string
+	EndpointConfig *EndpointConfig
+	Force          bool
+}
+
+// ListNetworks returns a slice of all networks known to the daemon.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) ListNetworks() ([]Network, error) {
+	body, _, err := c.do("GET", "/networks", nil)
+	if err != nil {
+		return nil, err
+	}
+	var networks []Network
+	err = json.Unmarshal(body, &networks)
+	if err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+// InspectNetwork returns a network by its ID.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) InspectNetwork(id string) (*Network, error) {
+	body, _, err := c.do("GET", "/networks/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	var network Network
+	err = json.Unmarshal(body, &network)
+	if err != nil {
+		return nil, err
+	}
+	return &network, nil
+}
+
+// CreateNetwork creates a network, returning the network instance, or an
+// error in case of failure.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) CreateNetwork(opts CreateNetworkOptions) (*Network, error) {
+	body, _, err := c.do("POST", "/networks/create", opts)
+	if err != nil {
+		return nil, err
+	}
+	var network Network
+	err = json.Unmarshal(body, &network)
+	if err != nil {
+		return nil, err
+	}
+	return &network, nil
+}
+
+// RemoveNetwork removes a network by its ID, returning an error in case of
+// failure.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) RemoveNetwork(id string) error {
+	_, _, err := c.do("DELETE", "/networks/"+id, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConnectNetwork connects a container to a network.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) ConnectNetwork(id string, opts NetworkConnectionOptions) error {
+	_, _, err := c.do("POST", "/networks/"+id+"/connect", opts)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DisconnectNetwork disconnects a container from a network.
+//
+// See http://goo.gl/6GugX2 for more details.
+func (c *Client) DisconnectNetwork(id string, opts NetworkConnectionOptions) error {
+	_, _, err := c.do("POST", "/networks/"+id+"/disconnect", opts)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2012 Graeme Connell. All rights reserved.
+// Copyright (c) 2009-2012 Andreas Krennmair. All rights reserved.
+
+package gopacket
+
+// PacketMetadata contains metadata for a packet that doesn't come from any
+// single layer, either because it arrives alongside the wire bytes (see
+// CaptureInfo) or because it's set by the decoding logic itself as it walks
+// the packet's layers.
+type PacketMetadata struct {
+	CaptureInfo
+	// Truncated is true if during decoding we ran out of bytes before we ran
+	// out of protocol, i.e. a layer's header claimed more data followed it
+	// than we actually had available.  A packet can be Truncated without any
+	// of its layers being a DecodeFailure: decoders set this via
+	// DecodeFeedback.SetTruncated instead of failing outright, so that a
+	// caller can tell "decoded, but cut short" apart from "decode failed".
+	Truncated bool
+}
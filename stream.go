@@ -0,0 +1,62 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// stream issues method/path to the daemon over a raw connection and hands
+// back the response body while the connection stays open, for endpoints
+// like /events that keep the response streaming indefinitely rather than
+// returning a fixed body. It's the non-upgrading counterpart to hijack:
+// callers only ever read the response, so there's no need to detect a
+// protocol switch or relay an input stream.
+func (c *Client) stream(method, path string, data interface{}) (io.ReadCloser, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyReader io.Reader
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, path, bodyReader)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		conn.Close()
+		return nil, fmt.Errorf("docker: %s %s: %s", method, path, body)
+	}
+	return resp.Body, nil
+}
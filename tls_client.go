@@ -0,0 +1,83 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// errInvalidCACertificate is returned when the CA bundle passed to
+// NewTLSClient/NewTLSClientFromBytes doesn't contain a valid certificate.
+var errInvalidCACertificate = errors.New("docker: could not add CA certificate to pool")
+
+// NewTLSClient returns a Client that authenticates with the Docker daemon
+// over TLS using the certificate, key, and CA bundle found at the given
+// paths. Use this instead of NewClient to talk to a daemon started with
+// --tlsverify, which is the default for anything other than the local
+// unix socket.
+func NewTLSClient(endpoint, cert, key, ca string) (*Client, error) {
+	certPEM, err := ioutil.ReadFile(cert)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(key)
+	if err != nil {
+		return nil, err
+	}
+	caPEM, err := ioutil.ReadFile(ca)
+	if err != nil {
+		return nil, err
+	}
+	return NewTLSClientFromBytes(endpoint, certPEM, keyPEM, caPEM)
+}
+
+// NewTLSClientFromBytes is like NewTLSClient, but takes the certificate,
+// key, and CA bundle as in-memory PEM data rather than file paths.
+func NewTLSClientFromBytes(endpoint string, certPEM, keyPEM, caPEM []byte) (*Client, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return nil, errInvalidCACertificate
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+	if strings.HasPrefix(endpoint, "unix://") {
+		// The daemon is on a local unix socket, so there's no TLS handshake
+		// to perform; the custom Dial here just reaches the socket, and
+		// TLSClientConfig is never consulted.
+		path := strings.TrimPrefix(endpoint, "unix://")
+		transport.Dial = func(network, addr string) (net.Conn, error) {
+			return net.Dial("unix", path)
+		}
+	}
+	// For a tcp:// endpoint we leave Dial unset: Transport dials a plain TCP
+	// conn itself and performs the TLS handshake on top of it using
+	// TLSClientConfig. Handshaking again here via tls.Dial would hand
+	// Transport an already-encrypted stream and it would try to negotiate
+	// TLS a second time on top of that, which fails the connection.
+	c, err := NewClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	c.HTTPClient = &http.Client{Transport: transport}
+	// c.dial (hijack.go) needs tlsConfig too: it opens its own raw
+	// connections for exec/attach/events traffic, bypassing HTTPClient's
+	// Transport entirely, so without this those requests would go out as
+	// plaintext TCP against a --tlsverify daemon.
+	c.tlsConfig = tlsConfig
+	return c, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2013 Francisco Souza. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docker
+
+import "encoding/json"
+
+// Volume represents a volume as reported by the /volumes API.
+//
+// See http://goo.gl/KtWBKA for more details.
+type Volume struct {
+	Name       string
+	Driver     string
+	Mountpoint string
+	Labels     map[string]string
+	Options    map[string]string
+	Scope      string
+}
+
+// ListVolumesOptions specify parameters to the ListVolumes function.
+//
+// See http://goo.gl/KtWBKA for more details.
+type ListVolumesOptions struct {
+	Filters map[string][]string
+}
+
+// CreateVolumeOptions specify parameters to the CreateVolume function.
+//
+// See http://goo.gl/KtWBKA for more details.
+type CreateVolumeOptions struct {
+	Name       string
+	Driver     string
+	DriverOpts map[string]string
+	Labels     map[string]string
+}
+
+// ListVolumes returns a slice of volumes matching the given criteria.
+//
+// See http://goo.gl/KtWBKA for more details.
+func (c *Client) ListVolumes(opts ListVolumesOptions) ([]Volume, error) {
+	path := "/volumes?" + queryString(opts)
+	body, _, err := c.do("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Volumes []Volume
+	}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Volumes, nil
+}
+
+// CreateVolume creates a volume, returning the volume instance, or an error
+// in case of failure.
+//
+// See http://goo.gl/KtWBKA for more details.
+func (c *Client) CreateVolume(opts CreateVolumeOptions) (*Volume, error) {
+	body, _, err := c.do("POST", "/volumes/create", opts)
+	if err != nil {
+		return nil, err
+	}
+	var volume Volume
+	err = json.Unmarshal(body, &volume)
+	if err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
+// InspectVolume returns a volume by its name.
+//
+// See http://goo.gl/KtWBKA for more details.
+func (c *Client) InspectVolume(name string) (*Volume, error) {
+	body, _, err := c.do("GET", "/volumes/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	var volume Volume
+	err = json.Unmarshal(body, &volume)
+	if err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
+// RemoveVolume removes a volume by its name, returning an error in case of
+// failure.
+//
+// See http://goo.gl/KtWBKA for more details.
+func (c *Client) RemoveVolume(name string) error {
+	_, _, err := c.do("DELETE", "/volumes/"+name, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}